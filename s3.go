@@ -7,16 +7,30 @@ import (
 	"fmt"
 	"io"
 	"log"
+	"net/url"
 	"os"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
 	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
 	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/aws/smithy-go/middleware"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+	"github.com/dop251/goja"
 
+	"go.k6.io/k6/js/common"
 	"go.k6.io/k6/js/modules"
+	"go.k6.io/k6/metrics"
 )
 
 func init() {
@@ -29,7 +43,8 @@ type RootModule struct{}
 
 // S3 represents an instance of the S3 module for every VU.
 type S3 struct {
-	vu modules.VU
+	vu      modules.VU
+	metrics s3Metrics
 }
 
 // Ensure the interfaces are implemented correctly.
@@ -41,7 +56,11 @@ var (
 // NewModuleInstance implements the modules.Module interface to return
 // a new instance for each VU.
 func (*RootModule) NewModuleInstance(vu modules.VU) modules.Instance {
-	return &S3{vu: vu}
+	m, err := registerMetrics(vu)
+	if err != nil {
+		log.Printf("Unable to register s3 metrics: %v\n", err)
+	}
+	return &S3{vu: vu, metrics: m}
 }
 
 // Exports implements the modules.Instance interface and returns the exports
@@ -61,18 +80,238 @@ func (*S3) RandomData(size int64) []byte {
 	return buf
 }
 
-// Creates a new S3 client from the given configuration.
-func (*S3) Create(accessKey, secretKey, endpoint, region string) (*s3.Client, error) {
-	creds := credentials.NewStaticCredentialsProvider(accessKey, secretKey, "")
-	customResolver := aws.EndpointResolverWithOptionsFunc(func(service, region string, options ...interface{}) (aws.Endpoint, error) {
+// s3Metrics holds the custom k6 metrics emitted for every S3 operation, so
+// they show up in the end-of-test summary, --out exporters, and can back
+// thresholds the same way k6's own built-in http_req_duration et al. do.
+type s3Metrics struct {
+	reqDuration   *metrics.Metric
+	bytesSent     *metrics.Metric
+	bytesReceived *metrics.Metric
+	retries       *metrics.Metric
+	reqFailed     *metrics.Metric
+}
+
+// Registers the module's custom metrics with the VU's metrics registry.
+func registerMetrics(vu modules.VU) (s3Metrics, error) {
+	registry := vu.InitEnv().Registry
+	var m s3Metrics
+	var err error
+	if m.reqDuration, err = registry.NewMetric("s3_req_duration", metrics.Trend, metrics.Time); err != nil {
+		return m, err
+	}
+	if m.bytesSent, err = registry.NewMetric("s3_bytes_sent", metrics.Counter, metrics.Data); err != nil {
+		return m, err
+	}
+	if m.bytesReceived, err = registry.NewMetric("s3_bytes_received", metrics.Counter, metrics.Data); err != nil {
+		return m, err
+	}
+	if m.retries, err = registry.NewMetric("s3_retries", metrics.Counter); err != nil {
+		return m, err
+	}
+	if m.reqFailed, err = registry.NewMetric("s3_req_failed", metrics.Rate); err != nil {
+		return m, err
+	}
+	return m, nil
+}
+
+// Pushes a single sample for metric m, tagged by operation/bucket/endpoint/
+// status, through the VU's metrics pipeline. A no-op outside a running
+// iteration (e.g. during init-context client setup) or if metric
+// registration failed.
+func (s *S3) pushSample(m *metrics.Metric, value float64, operation, bucketName, endpoint string, failed bool, extraTags map[string]string) {
+	if m == nil {
+		return
+	}
+	state := s.vu.State()
+	if state == nil {
+		return
+	}
+	status := "ok"
+	if failed {
+		status = "error"
+	}
+	tags := state.Tags.GetCurrentValues().Tags.
+		With("operation", operation).
+		With("bucket", bucketName).
+		With("endpoint", endpoint).
+		With("status", status)
+	for k, v := range extraTags {
+		tags = tags.With(k, v)
+	}
+	metrics.PushIfNotDone(s.vu.Context(), state.Samples, metrics.Sample{
+		TimeSeries: metrics.TimeSeries{Metric: m, Tags: tags},
+		Time:       time.Now(),
+		Value:      value,
+	})
+}
+
+// Returns an s3.Options mutator that installs a Smithy finalize middleware
+// measuring wall-clock duration, request/response byte counts, and retry
+// count for a single S3 operation, then pushes them through the VU's
+// metrics pipeline as s3_req_duration, s3_bytes_sent, s3_bytes_received,
+// s3_retries, and s3_req_failed.
+func (s *S3) instrumentAPIOptions(operation, bucketName string, extraTags ...map[string]string) func(*s3.Options) {
+	var merged map[string]string
+	for _, t := range extraTags {
+		if merged == nil {
+			merged = map[string]string{}
+		}
+		for k, v := range t {
+			merged[k] = v
+		}
+	}
+	return func(o *s3.Options) {
+		o.APIOptions = append(o.APIOptions, func(stack *middleware.Stack) error {
+			return stack.Finalize.Add(middleware.FinalizeMiddlewareFunc("S3Metrics",
+				func(ctx context.Context, in middleware.FinalizeInput, next middleware.FinalizeHandler) (
+					middleware.FinalizeOutput, middleware.Metadata, error,
+				) {
+					start := time.Now()
+					out, metadata, err := next.HandleFinalize(ctx, in)
+					duration := time.Since(start)
+
+					var endpoint string
+					var bytesSent int64
+					if req, ok := in.Request.(*smithyhttp.Request); ok && req != nil {
+						if req.URL != nil {
+							endpoint = req.URL.Host
+						}
+						bytesSent = req.ContentLength
+					}
+
+					var bytesReceived int64
+					if raw, ok := awsmiddleware.GetRawResponse(metadata).(*smithyhttp.Response); ok && raw != nil && raw.Response != nil {
+						bytesReceived = raw.ContentLength
+					}
+
+					retries := 0
+					if attempts, ok := retry.GetAttemptResults(metadata); ok {
+						retries = len(attempts.Results) - 1
+					}
+
+					s.pushSample(s.metrics.reqDuration, float64(duration.Milliseconds()), operation, bucketName, endpoint, err != nil, merged)
+					if bytesSent > 0 {
+						s.pushSample(s.metrics.bytesSent, float64(bytesSent), operation, bucketName, endpoint, err != nil, merged)
+					}
+					if bytesReceived > 0 {
+						s.pushSample(s.metrics.bytesReceived, float64(bytesReceived), operation, bucketName, endpoint, err != nil, merged)
+					}
+					if retries > 0 {
+						s.pushSample(s.metrics.retries, float64(retries), operation, bucketName, endpoint, err != nil, merged)
+					}
+					failed := 0.0
+					if err != nil {
+						failed = 1
+					}
+					s.pushSample(s.metrics.reqFailed, failed, operation, bucketName, endpoint, err != nil, merged)
+
+					return out, metadata, err
+				}), middleware.Before)
+		})
+	}
+}
+
+// Builds the static-endpoint resolver shared by every Create* constructor.
+// It only overrides the S3 endpoint: STS calls made internally by the
+// assume-role/web-identity constructors must still resolve to AWS's own STS
+// endpoints, and an empty endpoint falls back to default resolution so
+// scripts running on EC2/EKS/Lambda can use AWS's own S3 endpoints too.
+func endpointResolver(endpoint string) aws.EndpointResolverWithOptions {
+	return aws.EndpointResolverWithOptionsFunc(func(service, region string, options ...interface{}) (aws.Endpoint, error) {
+		if endpoint == "" || service != s3.ServiceID {
+			return aws.Endpoint{}, &aws.EndpointNotFoundError{}
+		}
 		return aws.Endpoint{
 			URL: endpoint,
 		}, nil
 	})
+}
+
+// Creates a new S3 client from the given configuration.
+func (*S3) Create(accessKey, secretKey, endpoint, region string) (*s3.Client, error) {
+	creds := credentials.NewStaticCredentialsProvider(accessKey, secretKey, "")
 	cfg, err := config.LoadDefaultConfig(context.Background(),
 		config.WithRegion(region),
 		config.WithCredentialsProvider(creds),
-		config.WithEndpointResolverWithOptions(customResolver))
+		config.WithEndpointResolverWithOptions(endpointResolver(endpoint)))
+	if err != nil {
+		log.Printf("Unable to load config: %v\n", err)
+		return nil, err
+	}
+	return s3.NewFromConfig(cfg, func(o *s3.Options) {
+		o.UsePathStyle = true
+	}), nil
+}
+
+// Creates a new S3 client using the AWS default credential chain (env vars,
+// shared config/credentials files, or EC2/ECS/EKS instance metadata) instead
+// of a static key pair, so k6 scripts can run against real AWS from
+// EC2/EKS/Lambda runners without embedding long-lived credentials.
+func (*S3) CreateWithDefaultChain(region, endpoint string) (*s3.Client, error) {
+	cfg, err := config.LoadDefaultConfig(context.Background(),
+		config.WithRegion(region),
+		config.WithEndpointResolverWithOptions(endpointResolver(endpoint)))
+	if err != nil {
+		log.Printf("Unable to load config: %v\n", err)
+		return nil, err
+	}
+	return s3.NewFromConfig(cfg, func(o *s3.Options) {
+		o.UsePathStyle = true
+	}), nil
+}
+
+// Creates a new S3 client that assumes roleArn via STS before issuing any
+// requests, refreshing the assumed-role credentials automatically as they
+// near expiry. sessionName and externalId may be left empty if the role's
+// trust policy does not require them.
+func (*S3) CreateWithAssumeRole(roleArn, sessionName, externalId, region, endpoint string) (*s3.Client, error) {
+	cfg, err := config.LoadDefaultConfig(context.Background(),
+		config.WithRegion(region),
+		config.WithEndpointResolverWithOptions(endpointResolver(endpoint)))
+	if err != nil {
+		log.Printf("Unable to load config: %v\n", err)
+		return nil, err
+	}
+	stsClient := sts.NewFromConfig(cfg)
+	cfg.Credentials = aws.NewCredentialsCache(stscreds.NewAssumeRoleProvider(stsClient, roleArn, func(o *stscreds.AssumeRoleOptions) {
+		if sessionName != "" {
+			o.RoleSessionName = sessionName
+		}
+		if externalId != "" {
+			o.ExternalID = aws.String(externalId)
+		}
+	}))
+	return s3.NewFromConfig(cfg, func(o *s3.Options) {
+		o.UsePathStyle = true
+	}), nil
+}
+
+// Creates a new S3 client that authenticates via an OIDC web identity token
+// read from tokenFile (the pattern used by EKS IRSA and GitHub Actions OIDC),
+// exchanging it for temporary credentials through STS.
+func (*S3) CreateWithWebIdentity(roleArn, tokenFile, region, endpoint string) (*s3.Client, error) {
+	cfg, err := config.LoadDefaultConfig(context.Background(),
+		config.WithRegion(region),
+		config.WithEndpointResolverWithOptions(endpointResolver(endpoint)))
+	if err != nil {
+		log.Printf("Unable to load config: %v\n", err)
+		return nil, err
+	}
+	stsClient := sts.NewFromConfig(cfg)
+	cfg.Credentials = aws.NewCredentialsCache(
+		stscreds.NewWebIdentityRoleProvider(stsClient, roleArn, stscreds.IdentityTokenFile(tokenFile)))
+	return s3.NewFromConfig(cfg, func(o *s3.Options) {
+		o.UsePathStyle = true
+	}), nil
+}
+
+// Creates a new S3 client using a named profile from the shared AWS
+// config/credentials files.
+func (*S3) CreateWithProfile(profile, region, endpoint string) (*s3.Client, error) {
+	cfg, err := config.LoadDefaultConfig(context.Background(),
+		config.WithRegion(region),
+		config.WithSharedConfigProfile(profile),
+		config.WithEndpointResolverWithOptions(endpointResolver(endpoint)))
 	if err != nil {
 		log.Printf("Unable to load config: %v\n", err)
 		return nil, err
@@ -82,21 +321,94 @@ func (*S3) Create(accessKey, secretKey, endpoint, region string) (*s3.Client, er
 	}), nil
 }
 
+// UploadOptions bundles the SSE, ACL, storage-class, and metadata knobs that
+// can be applied to an uploaded object. Zero-valued fields are left unset so
+// callers only need to populate what their workload cares about.
+type UploadOptions struct {
+	SSECustomerAlgorithm string
+	SSECustomerKey       string
+	SSEKMSKeyId          string
+	ServerSideEncryption string
+	ACL                  string
+	StorageClass         string
+	ContentType          string
+	ContentEncoding      string
+	CacheControl         string
+	Tagging              string
+	Metadata             map[string]string
+}
+
+// Applies an UploadOptions bag onto a PutObjectInput, leaving any field whose
+// option was not set at the SDK default.
+func applyUploadOptions(input *s3.PutObjectInput, opts UploadOptions) {
+	if opts.SSECustomerAlgorithm != "" {
+		input.SSECustomerAlgorithm = aws.String(opts.SSECustomerAlgorithm)
+	}
+	if opts.SSECustomerKey != "" {
+		input.SSECustomerKey = aws.String(opts.SSECustomerKey)
+	}
+	if opts.SSEKMSKeyId != "" {
+		input.SSEKMSKeyId = aws.String(opts.SSEKMSKeyId)
+	}
+	if opts.ServerSideEncryption != "" {
+		input.ServerSideEncryption = types.ServerSideEncryption(opts.ServerSideEncryption)
+	}
+	if opts.ACL != "" {
+		input.ACL = types.ObjectCannedACL(opts.ACL)
+	}
+	if opts.StorageClass != "" {
+		input.StorageClass = types.StorageClass(opts.StorageClass)
+	}
+	if opts.ContentType != "" {
+		input.ContentType = aws.String(opts.ContentType)
+	}
+	if opts.ContentEncoding != "" {
+		input.ContentEncoding = aws.String(opts.ContentEncoding)
+	}
+	if opts.CacheControl != "" {
+		input.CacheControl = aws.String(opts.CacheControl)
+	}
+	if opts.Tagging != "" {
+		input.Tagging = aws.String(opts.Tagging)
+	}
+	if opts.Metadata != nil {
+		input.Metadata = opts.Metadata
+	}
+}
+
+// DownloadOptions carries the SSE-C parameters needed to read an object that
+// was uploaded with a customer-provided encryption key.
+type DownloadOptions struct {
+	SSECustomerAlgorithm string
+	SSECustomerKey       string
+}
+
+// Applies a DownloadOptions bag onto a GetObjectInput.
+func applyDownloadOptions(input *s3.GetObjectInput, opts DownloadOptions) {
+	if opts.SSECustomerAlgorithm != "" {
+		input.SSECustomerAlgorithm = aws.String(opts.SSECustomerAlgorithm)
+	}
+	if opts.SSECustomerKey != "" {
+		input.SSECustomerKey = aws.String(opts.SSECustomerKey)
+	}
+}
+
 // Uploads the given file to the S3 bucket with the given key.
-func (*S3) UploadFile(client *s3.Client, bucketName, objectKey, fileName string) error {
+func (s *S3) UploadFile(client *s3.Client, bucketName, objectKey, fileName string, opts UploadOptions) error {
 	file, err := os.Open(fileName)
 	if err != nil {
 		log.Printf("Unable to open file %v to upload: %v\n", fileName, err)
 	} else {
 		defer file.Close()
-		_, err := client.PutObject(context.Background(),
-			&s3.PutObjectInput{
-				Bucket: aws.String(bucketName),
-				Key:    aws.String(objectKey),
-				Body:   file,
-			}, s3.WithAPIOptions(
-				v4.SwapComputePayloadSHA256ForUnsignedPayloadMiddleware,
-			))
+		input := &s3.PutObjectInput{
+			Bucket: aws.String(bucketName),
+			Key:    aws.String(objectKey),
+			Body:   file,
+		}
+		applyUploadOptions(input, opts)
+		_, err := client.PutObject(context.Background(), input, s3.WithAPIOptions(
+			v4.SwapComputePayloadSHA256ForUnsignedPayloadMiddleware,
+		), s.instrumentAPIOptions("UploadFile", bucketName))
 		if err != nil {
 			log.Printf("Unable to upload file %v to %v/%v: %v\n", fileName, bucketName, objectKey, err)
 		}
@@ -105,7 +417,7 @@ func (*S3) UploadFile(client *s3.Client, bucketName, objectKey, fileName string)
 }
 
 // Multipart upload of the given large file to the S3 bucket with the given key.
-func (*S3) UploadLargeFile(client *s3.Client, bucketName, objectKey, fileName string, partSize int64, concurrency int) error {
+func (s *S3) UploadLargeFile(client *s3.Client, bucketName, objectKey, fileName string, partSize int64, concurrency int, opts UploadOptions) error {
 	file, err := os.Open(fileName)
 	if err != nil {
 		log.Printf("Unable to open large file %v to upload: %v\n", fileName, err)
@@ -116,13 +428,15 @@ func (*S3) UploadLargeFile(client *s3.Client, bucketName, objectKey, fileName st
 			u.Concurrency = concurrency
 			u.ClientOptions = append(u.ClientOptions, s3.WithAPIOptions(
 				v4.SwapComputePayloadSHA256ForUnsignedPayloadMiddleware,
-			))
+			), s.instrumentAPIOptions("UploadLargeFile", bucketName))
 		})
-		_, err := uploader.Upload(context.Background(), &s3.PutObjectInput{
+		input := &s3.PutObjectInput{
 			Bucket: aws.String(bucketName),
 			Key:    aws.String(objectKey),
 			Body:   file,
-		})
+		}
+		applyUploadOptions(input, opts)
+		_, err := uploader.Upload(context.Background(), input)
 		if err != nil {
 			log.Printf("Unable to upload large file %v to %v/%v: %v\n", fileName, bucketName, objectKey, err)
 		}
@@ -131,15 +445,16 @@ func (*S3) UploadLargeFile(client *s3.Client, bucketName, objectKey, fileName st
 }
 
 // Uploads the given byte data to the S3 bucket with the given key.
-func (*S3) UploadData(client *s3.Client, bucketName, objectKey string, data []byte) error {
-	_, err := client.PutObject(context.Background(),
-		&s3.PutObjectInput{
-			Bucket: aws.String(bucketName),
-			Key:    aws.String(objectKey),
-			Body:   bytes.NewReader(data),
-		}, s3.WithAPIOptions(
-			v4.SwapComputePayloadSHA256ForUnsignedPayloadMiddleware,
-		))
+func (s *S3) UploadData(client *s3.Client, bucketName, objectKey string, data []byte, opts UploadOptions) error {
+	input := &s3.PutObjectInput{
+		Bucket: aws.String(bucketName),
+		Key:    aws.String(objectKey),
+		Body:   bytes.NewReader(data),
+	}
+	applyUploadOptions(input, opts)
+	_, err := client.PutObject(context.Background(), input, s3.WithAPIOptions(
+		v4.SwapComputePayloadSHA256ForUnsignedPayloadMiddleware,
+	), s.instrumentAPIOptions("UploadData", bucketName))
 	if err != nil {
 		log.Printf("Unable to upload bytes to %v/%v: %v\n", bucketName, objectKey, err)
 	}
@@ -147,13 +462,14 @@ func (*S3) UploadData(client *s3.Client, bucketName, objectKey string, data []by
 }
 
 // Downloads the given byte data from the S3 bucket with the given key.
-func (*S3) DownloadDataRange(client *s3.Client, bucketName, objectKey string, begin, end int) ([]byte, error) {
-	result, err := client.GetObject(context.Background(),
-		&s3.GetObjectInput{
-			Bucket: aws.String(bucketName),
-			Key:    aws.String(objectKey),
-			Range:  aws.String(fmt.Sprintf("bytes=%d-%d", begin, end)),
-		})
+func (s *S3) DownloadDataRange(client *s3.Client, bucketName, objectKey string, begin, end int, opts DownloadOptions) ([]byte, error) {
+	input := &s3.GetObjectInput{
+		Bucket: aws.String(bucketName),
+		Key:    aws.String(objectKey),
+		Range:  aws.String(fmt.Sprintf("bytes=%d-%d", begin, end)),
+	}
+	applyDownloadOptions(input, opts)
+	result, err := client.GetObject(context.Background(), input, s.instrumentAPIOptions("DownloadDataRange", bucketName))
 	if err != nil {
 		log.Printf("Unable to download bytes from %v/%v: %v\n", bucketName, objectKey, err)
 		return nil, err
@@ -165,3 +481,315 @@ func (*S3) DownloadDataRange(client *s3.Client, bucketName, objectKey string, be
 	}
 	return bytes, err
 }
+
+// progressWriterAt wraps an io.WriterAt and reports cumulative bytes written
+// after every write so callers can drive k6 custom trends for throughput;
+// the downloader writes each part in several buffer-sized pieces, so this
+// fires several times per part. s3manager.Downloader calls WriteAt
+// concurrently from its worker goroutines (up to Concurrency of them, each
+// working on one part at a time), so written is guarded by a mutex and
+// onProgress is dispatched through enqueue rather than invoked directly,
+// since the goja runtime may only be touched from the VU's own event-loop
+// goroutine. enqueue must be the function returned by a vu.RegisterCallback
+// call already made on the VU goroutine — unlike RegisterCallback itself,
+// that returned function is safe to call from any goroutine.
+type progressWriterAt struct {
+	enqueue    func(func() error)
+	w          io.WriterAt
+	onProgress func(bytesDownloaded int64)
+
+	mu      sync.Mutex
+	written int64
+}
+
+func (p *progressWriterAt) WriteAt(data []byte, offset int64) (int, error) {
+	n, err := p.w.WriteAt(data, offset)
+	if n > 0 && p.onProgress != nil {
+		p.mu.Lock()
+		p.written += int64(n)
+		written := p.written
+		p.mu.Unlock()
+
+		p.enqueue(func() error {
+			p.onProgress(written)
+			return nil
+		})
+	}
+	return n, err
+}
+
+// Downloads the given large file from the S3 bucket with the given key using
+// parallel ranged GETs, mirroring UploadLargeFile's part-size/concurrency
+// knobs. Runs the download in the background and returns a Promise that
+// resolves once it completes, so the VU's event loop isn't blocked for the
+// whole download; onProgress, if non-nil, is invoked with the cumulative
+// bytes downloaded each time a piece of a part is written, in real time
+// rather than after the fact.
+func (s *S3) DownloadLargeFile(client *s3.Client, bucketName, objectKey, fileName string, partSize int64, concurrency int, onProgress func(bytesDownloaded int64)) *goja.Promise {
+	promise, resolve, reject := common.MakeHandledPromise(s.vu)
+	enqueueProgress := s.vu.RegisterCallback()
+	go func() {
+		file, err := os.Create(fileName)
+		if err != nil {
+			log.Printf("Unable to create file %v to download into: %v\n", fileName, err)
+			reject(err)
+			return
+		}
+		defer file.Close()
+
+		downloader := manager.NewDownloader(client, func(d *manager.Downloader) {
+			d.PartSize = partSize
+			d.Concurrency = concurrency
+			d.ClientOptions = append(d.ClientOptions, s.instrumentAPIOptions("DownloadLargeFile", bucketName))
+		})
+		_, err = downloader.Download(context.Background(), &progressWriterAt{enqueue: enqueueProgress, w: file, onProgress: onProgress},
+			&s3.GetObjectInput{
+				Bucket: aws.String(bucketName),
+				Key:    aws.String(objectKey),
+			})
+		if err != nil {
+			log.Printf("Unable to download large file %v from %v/%v: %v\n", fileName, bucketName, objectKey, err)
+			reject(err)
+			return
+		}
+		resolve(nil)
+	}()
+	return promise
+}
+
+// Downloads the given large object from the S3 bucket with the given key
+// into memory using parallel ranged GETs, mirroring UploadLargeFile's
+// part-size/concurrency knobs. Runs the download in the background and
+// returns a Promise that resolves with the downloaded bytes once it
+// completes, so the VU's event loop isn't blocked for the whole download;
+// onProgress, if non-nil, is invoked with the cumulative bytes downloaded
+// each time a piece of a part is written, in real time rather than after the
+// fact.
+func (s *S3) DownloadLargeData(client *s3.Client, bucketName, objectKey string, partSize int64, concurrency int, onProgress func(bytesDownloaded int64)) *goja.Promise {
+	promise, resolve, reject := common.MakeHandledPromise(s.vu)
+	enqueueProgress := s.vu.RegisterCallback()
+	go func() {
+		buf := manager.NewWriteAtBuffer([]byte{})
+		downloader := manager.NewDownloader(client, func(d *manager.Downloader) {
+			d.PartSize = partSize
+			d.Concurrency = concurrency
+			d.ClientOptions = append(d.ClientOptions, s.instrumentAPIOptions("DownloadLargeData", bucketName))
+		})
+		_, err := downloader.Download(context.Background(), &progressWriterAt{enqueue: enqueueProgress, w: buf, onProgress: onProgress},
+			&s3.GetObjectInput{
+				Bucket: aws.String(bucketName),
+				Key:    aws.String(objectKey),
+			})
+		if err != nil {
+			log.Printf("Unable to download large data from %v/%v: %v\n", bucketName, objectKey, err)
+			reject(err)
+			return
+		}
+		resolve(buf.Bytes())
+	}()
+	return promise
+}
+
+// Deletes the given object from the S3 bucket.
+func (s *S3) DeleteObject(client *s3.Client, bucketName, objectKey string) error {
+	_, err := client.DeleteObject(context.Background(), &s3.DeleteObjectInput{
+		Bucket: aws.String(bucketName),
+		Key:    aws.String(objectKey),
+	}, s.instrumentAPIOptions("DeleteObject", bucketName))
+	if err != nil {
+		log.Printf("Unable to delete %v/%v: %v\n", bucketName, objectKey, err)
+	}
+	return err
+}
+
+// deleteObjectsBatchLimit is the maximum number of keys the DeleteObjects
+// API accepts in a single request.
+const deleteObjectsBatchLimit = 1000
+
+// Deletes every key in objectKeys from the S3 bucket, chunking into
+// 1000-key DeleteObjects calls (the API's limit) so teardown at the end of
+// a scenario stays fast. Returns the per-key errors the API reported, if
+// any, so callers can tell which keys failed to delete.
+func (s *S3) BatchDeleteObjects(client *s3.Client, bucketName string, objectKeys []string) ([]types.Error, error) {
+	var batchErrors []types.Error
+	for start := 0; start < len(objectKeys); start += deleteObjectsBatchLimit {
+		end := start + deleteObjectsBatchLimit
+		if end > len(objectKeys) {
+			end = len(objectKeys)
+		}
+		objects := make([]types.ObjectIdentifier, end-start)
+		for i, key := range objectKeys[start:end] {
+			objects[i] = types.ObjectIdentifier{Key: aws.String(key)}
+		}
+		output, err := client.DeleteObjects(context.Background(), &s3.DeleteObjectsInput{
+			Bucket: aws.String(bucketName),
+			Delete: &types.Delete{Objects: objects},
+		}, s.instrumentAPIOptions("BatchDeleteObjects", bucketName))
+		if err != nil {
+			log.Printf("Unable to batch delete %v objects from %v: %v\n", len(objects), bucketName, err)
+			return batchErrors, err
+		}
+		batchErrors = append(batchErrors, output.Errors...)
+	}
+	return batchErrors, nil
+}
+
+// Lists every object key under prefix in the bucket, paging internally via
+// s3.NewListObjectsV2Paginator.
+func (s *S3) ListObjectsV2(client *s3.Client, bucketName, prefix string) ([]string, error) {
+	paginator := s3.NewListObjectsV2Paginator(client, &s3.ListObjectsV2Input{
+		Bucket: aws.String(bucketName),
+		Prefix: aws.String(prefix),
+	})
+	var keys []string
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(context.Background(), s.instrumentAPIOptions("ListObjectsV2", bucketName))
+		if err != nil {
+			log.Printf("Unable to list objects in %v with prefix %v: %v\n", bucketName, prefix, err)
+			return nil, err
+		}
+		for _, obj := range page.Contents {
+			keys = append(keys, aws.ToString(obj.Key))
+		}
+	}
+	return keys, nil
+}
+
+// Retrieves metadata for the given object without downloading its body, for
+// existence probes in mixed-workload scenarios.
+func (s *S3) HeadObject(client *s3.Client, bucketName, objectKey string) (*s3.HeadObjectOutput, error) {
+	result, err := client.HeadObject(context.Background(), &s3.HeadObjectInput{
+		Bucket: aws.String(bucketName),
+		Key:    aws.String(objectKey),
+	}, s.instrumentAPIOptions("HeadObject", bucketName))
+	if err != nil {
+		log.Printf("Unable to head %v/%v: %v\n", bucketName, objectKey, err)
+		return nil, err
+	}
+	return result, nil
+}
+
+// escapeCopySourceKey percent-encodes a key for use in CopySource, leaving
+// "/" unescaped since it's a literal, non-separator character permitted in
+// S3 keys that CopySource expects to see verbatim. CopySource is a path,
+// not a query string, so url.PathEscape is used to get "%20" rather than
+// "+" for spaces, which S3 does not decode back.
+func escapeCopySourceKey(key string) string {
+	segments := strings.Split(key, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+// Copies the object at srcBucket/srcKey to dstBucket/dstKey, for modelling
+// copy-on-write workloads.
+func (s *S3) CopyObject(client *s3.Client, srcBucket, srcKey, dstBucket, dstKey string) error {
+	_, err := client.CopyObject(context.Background(), &s3.CopyObjectInput{
+		Bucket:     aws.String(dstBucket),
+		Key:        aws.String(dstKey),
+		CopySource: aws.String(fmt.Sprintf("%s/%s", srcBucket, escapeCopySourceKey(srcKey))),
+	}, s.instrumentAPIOptions("CopyObject", dstBucket, map[string]string{"sourceBucket": srcBucket}))
+	if err != nil {
+		log.Printf("Unable to copy %v/%v to %v/%v: %v\n", srcBucket, srcKey, dstBucket, dstKey, err)
+	}
+	return err
+}
+
+// PresignedRequest carries a presigned S3 URL and the headers a k6 script
+// must send alongside it for the signature to validate.
+type PresignedRequest struct {
+	URL          string              `json:"url"`
+	Method       string              `json:"method"`
+	SignedHeader map[string][]string `json:"signedHeader"`
+}
+
+// PresignGetObjectOptions configures an optional presigned GET request.
+type PresignGetObjectOptions struct {
+	Range                string
+	SSECustomerAlgorithm string
+	SSECustomerKey       string
+}
+
+// Presigns a GET request for the given object, valid for expirySeconds.
+func (*S3) PresignGetObject(client *s3.Client, bucketName, objectKey string, expirySeconds int64, opts PresignGetObjectOptions) (*PresignedRequest, error) {
+	input := &s3.GetObjectInput{
+		Bucket: aws.String(bucketName),
+		Key:    aws.String(objectKey),
+	}
+	if opts.Range != "" {
+		input.Range = aws.String(opts.Range)
+	}
+	if opts.SSECustomerAlgorithm != "" {
+		input.SSECustomerAlgorithm = aws.String(opts.SSECustomerAlgorithm)
+	}
+	if opts.SSECustomerKey != "" {
+		input.SSECustomerKey = aws.String(opts.SSECustomerKey)
+	}
+	presignClient := s3.NewPresignClient(client)
+	req, err := presignClient.PresignGetObject(context.Background(), input,
+		s3.WithPresignExpires(time.Duration(expirySeconds)*time.Second))
+	if err != nil {
+		log.Printf("Unable to presign GET for %v/%v: %v\n", bucketName, objectKey, err)
+		return nil, err
+	}
+	return &PresignedRequest{URL: req.URL, Method: req.Method, SignedHeader: req.SignedHeader}, nil
+}
+
+// PresignPutObjectOptions configures an optional presigned PUT request.
+type PresignPutObjectOptions struct {
+	ContentType          string
+	ServerSideEncryption string
+	SSECustomerAlgorithm string
+	SSECustomerKey       string
+	SSEKMSKeyId          string
+}
+
+// Presigns a PUT request for the given object, valid for expirySeconds.
+func (*S3) PresignPutObject(client *s3.Client, bucketName, objectKey string, expirySeconds int64, opts PresignPutObjectOptions) (*PresignedRequest, error) {
+	input := &s3.PutObjectInput{
+		Bucket: aws.String(bucketName),
+		Key:    aws.String(objectKey),
+	}
+	if opts.ContentType != "" {
+		input.ContentType = aws.String(opts.ContentType)
+	}
+	if opts.ServerSideEncryption != "" {
+		input.ServerSideEncryption = types.ServerSideEncryption(opts.ServerSideEncryption)
+	}
+	if opts.SSECustomerAlgorithm != "" {
+		input.SSECustomerAlgorithm = aws.String(opts.SSECustomerAlgorithm)
+	}
+	if opts.SSECustomerKey != "" {
+		input.SSECustomerKey = aws.String(opts.SSECustomerKey)
+	}
+	if opts.SSEKMSKeyId != "" {
+		input.SSEKMSKeyId = aws.String(opts.SSEKMSKeyId)
+	}
+	presignClient := s3.NewPresignClient(client)
+	req, err := presignClient.PresignPutObject(context.Background(), input,
+		s3.WithPresignExpires(time.Duration(expirySeconds)*time.Second))
+	if err != nil {
+		log.Printf("Unable to presign PUT for %v/%v: %v\n", bucketName, objectKey, err)
+		return nil, err
+	}
+	return &PresignedRequest{URL: req.URL, Method: req.Method, SignedHeader: req.SignedHeader}, nil
+}
+
+// Presigns a single UploadPart request so a k6 script can drive parallel
+// multipart upload load tests entirely through k6's native http module.
+func (*S3) PresignUploadPart(client *s3.Client, bucketName, objectKey, uploadId string, partNumber int32, expirySeconds int64) (*PresignedRequest, error) {
+	presignClient := s3.NewPresignClient(client)
+	req, err := presignClient.PresignUploadPart(context.Background(),
+		&s3.UploadPartInput{
+			Bucket:     aws.String(bucketName),
+			Key:        aws.String(objectKey),
+			UploadId:   aws.String(uploadId),
+			PartNumber: partNumber,
+		}, s3.WithPresignExpires(time.Duration(expirySeconds)*time.Second))
+	if err != nil {
+		log.Printf("Unable to presign UploadPart %v for %v/%v: %v\n", partNumber, bucketName, objectKey, err)
+		return nil, err
+	}
+	return &PresignedRequest{URL: req.URL, Method: req.Method, SignedHeader: req.SignedHeader}, nil
+}